@@ -0,0 +1,63 @@
+package custom
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// reorder rearranges b from the given word order into plain big-endian
+// (ABCD) so the standard encoding/binary readers can be used on it.
+func reorder(b []byte, order ByteOrder) []byte {
+	out := make([]byte, len(b))
+
+	switch order {
+	case "", ABCD:
+		copy(out, b)
+	case DCBA:
+		for i := range b {
+			out[i] = b[len(b)-1-i]
+		}
+	case BADC:
+		for i := 0; i+1 < len(b); i += 2 {
+			out[i], out[i+1] = b[i+1], b[i]
+		}
+	case CDAB:
+		copy(out, b)
+		for i := 0; i+3 < len(b); i += 4 {
+			out[i], out[i+1], out[i+2], out[i+3] = b[i+2], b[i+3], b[i], b[i+1]
+		}
+	default:
+		copy(out, b)
+	}
+
+	return out
+}
+
+// decodeValue converts the raw registers of a single field into a float64,
+// honouring its data type and byte order. Scaling is applied by the
+// caller.
+func decodeValue(b []byte, typ DataType, order ByteOrder) (float64, error) {
+	b = reorder(b, order)
+
+	switch typ {
+	case Int16:
+		return float64(int16(binary.BigEndian.Uint16(b))), nil
+	case Uint16:
+		return float64(binary.BigEndian.Uint16(b)), nil
+	case Int32:
+		return float64(int32(binary.BigEndian.Uint32(b))), nil
+	case Uint32:
+		return float64(binary.BigEndian.Uint32(b)), nil
+	case Int64:
+		return float64(int64(binary.BigEndian.Uint64(b))), nil
+	case Uint64:
+		return float64(binary.BigEndian.Uint64(b)), nil
+	case Float32:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case Float64:
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	default:
+		return 0, fmt.Errorf("unsupported data type %q", typ)
+	}
+}
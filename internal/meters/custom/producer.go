@@ -0,0 +1,183 @@
+package custom
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/RichieB2B/mbmd/internal/meters"
+)
+
+// modbusMaxReadRegisters is the largest register count a single Modbus
+// read can request (the protocol's function code 3/4 count field is a
+// byte count capped at 250 bytes, i.e. 125 registers). Block coalescing
+// must split at this ceiling instead of growing one block without bound.
+const modbusMaxReadRegisters = 125
+
+// block is a coalesced run of adjacent fields read via a single Modbus
+// request, the same trick SunSpecCore.scaleSnip16 uses to minimise
+// round-trips.
+type block struct {
+	funcCode meters.FuncCode
+	start    uint16
+	length   uint16
+	fields   []Field // ascending address order, contiguous within the block
+}
+
+// Producer implements meters.Producer for a user-defined register map.
+type Producer struct {
+	name   string
+	blocks []block
+}
+
+// weightedField pairs a Field with its already-resolved FuncCode, so
+// sorting and block-coalescing compare the resolved code rather than the
+// raw config string (where "" and "holding" mean the same thing but would
+// otherwise sort apart).
+type weightedField struct {
+	field Field
+	fc    meters.FuncCode
+}
+
+// NewProducer builds a Producer from a parsed Config, coalescing adjacent
+// fields that share a function code into as few block reads as possible.
+func NewProducer(cfg Config) (*Producer, error) {
+	fields := make([]weightedField, len(cfg.Fields))
+	for i, f := range cfg.Fields {
+		fc, err := funcCode(f.FuncCode)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		fields[i] = weightedField{field: f, fc: fc}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].fc != fields[j].fc {
+			return fields[i].fc < fields[j].fc
+		}
+		return fields[i].field.Address < fields[j].field.Address
+	})
+
+	var blocks []block
+	for _, wf := range fields {
+		f := wf.field
+
+		n := f.registers()
+		if n == 0 {
+			return nil, fmt.Errorf("%s: unsupported type %q", f.Name, f.Type)
+		}
+
+		if len(blocks) > 0 {
+			last := &blocks[len(blocks)-1]
+			if last.funcCode == wf.fc && f.Address == last.start+last.length && last.length+n <= modbusMaxReadRegisters {
+				last.length += n
+				last.fields = append(last.fields, f)
+				continue
+			}
+		}
+
+		blocks = append(blocks, block{funcCode: wf.fc, start: f.Address, length: n, fields: []Field{f}})
+	}
+
+	return &Producer{name: cfg.Name, blocks: blocks}, nil
+}
+
+func funcCode(s string) (meters.FuncCode, error) {
+	switch strings.ToLower(s) {
+	case "", "holding":
+		return meters.ReadHoldingReg, nil
+	case "input":
+		return meters.ReadInputReg, nil
+	default:
+		return 0, fmt.Errorf("unknown function code %q", s)
+	}
+}
+
+// Produce implements meters.Producer: one Operation per coalesced block,
+// each splitting its raw result into the individual fields it covers.
+func (p *Producer) Produce() (res []meters.Operation) {
+	for _, blk := range p.blocks {
+		blk := blk
+		res = append(res, meters.Operation{
+			FuncCode: blk.funcCode,
+			OpCode:   blk.start,
+			ReadLen:  blk.length,
+			IEC61850: meters.Split,
+			Splitter: blk.split,
+		})
+	}
+	return res
+}
+
+// split decodes a block's raw register bytes into one SplitResult per
+// field. STRING fields are informational (e.g. a device name) and are not
+// numeric measurements, so they are skipped here.
+func (blk block) split(b []byte) []meters.SplitResult {
+	res := make([]meters.SplitResult, 0, len(blk.fields))
+
+	offset := uint16(0)
+	for _, f := range blk.fields {
+		n := f.registers()
+		raw := b[2*offset : 2*(offset+n)]
+		addr := blk.start + offset
+		offset += n
+
+		if f.Type == String {
+			continue
+		}
+
+		val, err := decodeValue(raw, f.Type, f.Order)
+		if err != nil {
+			continue
+		}
+
+		scale := f.Scale
+		if scale == 0 {
+			scale = 1
+		}
+
+		res = append(res, meters.SplitResult{
+			OpCode:   addr,
+			IEC61850: meters.Measurement(f.Measurement),
+			Value:    val * scale,
+		})
+	}
+
+	return res
+}
+
+// Register loads every register map file (*.toml, *.yaml, *.yml) in dir
+// and registers the resulting Producer with mbmd's producer registry
+// under the name declared in the file, making it selectable via
+// "-d name:id" just like a built-in meter.
+func Register(dir string) error {
+	var matches []string
+	for _, pattern := range []string{"*.toml", "*.yaml", "*.yml"} {
+		m, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return err
+		}
+		matches = append(matches, m...)
+	}
+
+	for _, path := range matches {
+		cfg, err := LoadFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		if _, err := NewProducer(cfg); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		meters.Register(cfg.Name, func() meters.Producer {
+			// NewProducer already succeeded once above; a parsed Config
+			// cannot fail the second time round, so the error is discarded.
+			producer, _ := NewProducer(cfg)
+			return producer
+		})
+	}
+
+	return nil
+}
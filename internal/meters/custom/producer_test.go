@@ -0,0 +1,80 @@
+package custom
+
+import (
+	"testing"
+)
+
+func field(name string, addr uint16, typ DataType) Field {
+	return Field{Name: name, Address: addr, Type: typ, Measurement: name}
+}
+
+func TestNewProducerCoalescesContiguousFields(t *testing.T) {
+	cfg := Config{
+		Name: "test",
+		Fields: []Field{
+			field("a", 0, Uint16),
+			field("b", 1, Uint16),
+			field("c", 2, Uint16),
+		},
+	}
+
+	p, err := NewProducer(cfg)
+	if err != nil {
+		t.Fatalf("NewProducer: %v", err)
+	}
+
+	if len(p.blocks) != 1 {
+		t.Fatalf("got %d blocks, want 1 coalesced block: %+v", len(p.blocks), p.blocks)
+	}
+	if p.blocks[0].length != 3 {
+		t.Errorf("block length = %d, want 3", p.blocks[0].length)
+	}
+}
+
+func TestNewProducerSplitsNonContiguousFields(t *testing.T) {
+	cfg := Config{
+		Name: "test",
+		Fields: []Field{
+			field("a", 0, Uint16),
+			field("b", 5, Uint16), // gap - must not merge with "a"
+		},
+	}
+
+	p, err := NewProducer(cfg)
+	if err != nil {
+		t.Fatalf("NewProducer: %v", err)
+	}
+
+	if len(p.blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2: %+v", len(p.blocks), p.blocks)
+	}
+}
+
+func TestNewProducerSplitsAtModbusRegisterLimit(t *testing.T) {
+	// 130 contiguous UINT16 fields would coalesce past the 125-register
+	// ceiling a single Modbus read can request if left unbounded.
+	var fields []Field
+	for i := uint16(0); i < 130; i++ {
+		fields = append(fields, field("f", i, Uint16))
+	}
+
+	p, err := NewProducer(Config{Name: "test", Fields: fields})
+	if err != nil {
+		t.Fatalf("NewProducer: %v", err)
+	}
+
+	if len(p.blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2: %+v", len(p.blocks), p.blocks)
+	}
+
+	for _, blk := range p.blocks {
+		if blk.length > modbusMaxReadRegisters {
+			t.Errorf("block length %d exceeds modbusMaxReadRegisters (%d)", blk.length, modbusMaxReadRegisters)
+		}
+	}
+
+	total := p.blocks[0].length + p.blocks[1].length
+	if total != 130 {
+		t.Errorf("total registers across blocks = %d, want 130", total)
+	}
+}
@@ -0,0 +1,45 @@
+package meters
+
+// Measurement identifiers used by the ALE3 RS485 producer for its
+// dual-tariff energy counters - the single Import/Export measurements
+// cannot carry both tariffs at once.
+const (
+	// ImportT1 is the tariff 1 (e.g. off-peak) import energy counter.
+	ImportT1 Measurement = "ImportT1"
+
+	// ImportT2 is the tariff 2 (e.g. peak) import energy counter.
+	ImportT2 Measurement = "ImportT2"
+
+	// ExportT1 is the tariff 1 export energy counter, populated only on
+	// the bidirectional ALE3 variant.
+	ExportT1 Measurement = "ExportT1"
+
+	// ExportT2 is the tariff 2 export energy counter, populated only on
+	// the bidirectional ALE3 variant.
+	ExportT2 Measurement = "ExportT2"
+)
+
+// Measurement identifiers used by the Sungrow RS485 producer that are not
+// part of the standard set: daily/lifetime yield and hybrid battery
+// telemetry have no IEC61850-style equivalent upstream.
+const (
+	// ImportDaily is the daily yield counter, reset at local midnight,
+	// reported separately from the lifetime Import counter.
+	ImportDaily Measurement = "ImportDaily"
+
+	// OperatingTime is the inverter's cumulative running hours.
+	OperatingTime Measurement = "OperatingTime"
+
+	// ChargeState is the hybrid battery's state of charge, in percent.
+	ChargeState Measurement = "ChargeState"
+
+	// BatteryPower is the hybrid battery's charge/discharge power; positive
+	// charges, negative discharges.
+	BatteryPower Measurement = "BatteryPower"
+
+	// BatteryVoltage is the hybrid battery's terminal voltage.
+	BatteryVoltage Measurement = "BatteryVoltage"
+
+	// BatteryCurrent is the hybrid battery's charge/discharge current.
+	BatteryCurrent Measurement = "BatteryCurrent"
+)
@@ -0,0 +1,133 @@
+package rs485
+
+import (
+	"github.com/RichieB2B/mbmd/internal/meters"
+)
+
+// ALE3 register addresses (0-based holding registers). EXPERIMENTAL: these
+// were transcribed from a Saia Burgess Controls ALE3 Modbus interface
+// description without access to a real device to validate them against,
+// so treat readings with suspicion and confirm against your own firmware's
+// documentation before relying on them; adjust for a given revision. The
+// ALE3 is commonly paired with Viessmann heat pumps.
+const (
+	// ale3DiscriminatorReg is the ALE3's device identification register,
+	// which returns a fixed manufacturer-assigned device code on an ALE3
+	// where an SDM630 would return its own, letting autodetect tell the
+	// two apart. It must be a register outside the measurement block below
+	// (0x0000 is ale3VoltageL1, a live reading, not a magic constant).
+	ale3DiscriminatorReg = 0x0050
+
+	ale3VoltageL1 = 0x0000 // uint16, x0.1 V
+	ale3VoltageL2 = 0x0002
+	ale3VoltageL3 = 0x0004
+	ale3CurrentL1 = 0x0006 // uint16, x0.01 A
+	ale3CurrentL2 = 0x0008
+	ale3CurrentL3 = 0x000A
+
+	ale3PowerL1 = 0x000C // int16, x0.1 W
+	ale3PowerL2 = 0x000E
+	ale3PowerL3 = 0x0010
+
+	ale3ReactivePowerL1 = 0x0012 // int16, x0.1 var
+	ale3ReactivePowerL2 = 0x0014
+	ale3ReactivePowerL3 = 0x0016
+
+	ale3PowerFactorL1 = 0x0018 // uint16, x0.001
+	ale3PowerFactorL2 = 0x001A
+	ale3PowerFactorL3 = 0x001C
+
+	ale3Freq = 0x001E // uint16, x0.01 Hz
+
+	ale3ImportT1 = 0x0020 // uint32, x0.01 kWh, tariff 1
+	ale3ImportT2 = 0x0022 // uint32, x0.01 kWh, tariff 2
+	// export tariffs are only populated on the bidirectional ALE3 variant;
+	// like any other snip an unpopulated register simply reads back zero.
+	ale3ExportT1 = 0x0024
+	ale3ExportT2 = 0x0026
+)
+
+// snipTariffPair reads two consecutive uint32 tariff registers (T1
+// directly followed by T2) in a single request and produces three
+// SplitResults: the two individual tariffs plus their sum under sumIEC.
+func snipTariffPair(addr uint16, t1, t2, sumIEC meters.Measurement, scale float64) meters.Operation {
+	return meters.Operation{
+		FuncCode: meters.ReadHoldingReg,
+		OpCode:   addr,
+		ReadLen:  4, // two uint32 registers
+		IEC61850: meters.Split,
+		Splitter: func(b []byte) []meters.SplitResult {
+			v1 := scale * meters.RTUUint32ToFloat64(b[0:4])
+			v2 := scale * meters.RTUUint32ToFloat64(b[4:8])
+
+			return []meters.SplitResult{
+				{OpCode: addr, IEC61850: t1, Value: v1},
+				{OpCode: addr + 2, IEC61850: t2, Value: v2},
+				{OpCode: addr, IEC61850: sumIEC, Value: v1 + v2},
+			}
+		},
+	}
+}
+
+// ALE3 decodes the Saia Burgess Controls ALE3 energy meter.
+type ALE3 struct{}
+
+// NewALE3Producer creates an ALE3 producer.
+func NewALE3Producer() *ALE3 {
+	return &ALE3{}
+}
+
+func (p *ALE3) Type() string {
+	return "ALE3"
+}
+
+func (p *ALE3) Description() string {
+	return "Saia Burgess Controls ALE3 energy meter (experimental, unverified register map)"
+}
+
+// Probe reads the discriminator register used to tell an ALE3 apart from
+// an SDM630 during RS485 autodetect.
+func (p *ALE3) Probe() meters.Operation {
+	return meters.Operation{
+		FuncCode: meters.ReadHoldingReg,
+		OpCode:   ale3DiscriminatorReg,
+		ReadLen:  1,
+	}
+}
+
+// Produce implements meters.Producer.
+func (p *ALE3) Produce() (res []meters.Operation) {
+	res = append(res,
+		snip16(meters.ReadHoldingReg, ale3VoltageL1, meters.VoltageL1, 0.1),
+		snip16(meters.ReadHoldingReg, ale3VoltageL2, meters.VoltageL2, 0.1),
+		snip16(meters.ReadHoldingReg, ale3VoltageL3, meters.VoltageL3, 0.1),
+		snip16(meters.ReadHoldingReg, ale3CurrentL1, meters.CurrentL1, 0.01),
+		snip16(meters.ReadHoldingReg, ale3CurrentL2, meters.CurrentL2, 0.01),
+		snip16(meters.ReadHoldingReg, ale3CurrentL3, meters.CurrentL3, 0.01),
+
+		snip16Signed(meters.ReadHoldingReg, ale3PowerL1, meters.PowerL1, 0.1),
+		snip16Signed(meters.ReadHoldingReg, ale3PowerL2, meters.PowerL2, 0.1),
+		snip16Signed(meters.ReadHoldingReg, ale3PowerL3, meters.PowerL3, 0.1),
+
+		snip16Signed(meters.ReadHoldingReg, ale3ReactivePowerL1, meters.ReactivePowerL1, 0.1),
+		snip16Signed(meters.ReadHoldingReg, ale3ReactivePowerL2, meters.ReactivePowerL2, 0.1),
+		snip16Signed(meters.ReadHoldingReg, ale3ReactivePowerL3, meters.ReactivePowerL3, 0.1),
+
+		snip16(meters.ReadHoldingReg, ale3PowerFactorL1, meters.CosphiL1, 0.001),
+		snip16(meters.ReadHoldingReg, ale3PowerFactorL2, meters.CosphiL2, 0.001),
+		snip16(meters.ReadHoldingReg, ale3PowerFactorL3, meters.CosphiL3, 0.001),
+
+		snip16(meters.ReadHoldingReg, ale3Freq, meters.Freq, 0.01),
+
+		snipTariffPair(ale3ImportT1, meters.ImportT1, meters.ImportT2, meters.Import, 0.01),
+		snipTariffPair(ale3ExportT1, meters.ExportT1, meters.ExportT2, meters.Export, 0.01),
+	)
+
+	return res
+}
+
+func init() {
+	meters.Register(ALE3{}.Type(), func() meters.Producer {
+		return NewALE3Producer()
+	})
+}
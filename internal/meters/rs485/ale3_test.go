@@ -0,0 +1,40 @@
+package rs485
+
+import (
+	"testing"
+
+	"github.com/RichieB2B/mbmd/internal/meters"
+)
+
+func TestSnipTariffPairSplitter(t *testing.T) {
+	op := snipTariffPair(ale3ImportT1, meters.ImportT1, meters.ImportT2, meters.Import, 0.01)
+
+	if op.ReadLen != 4 {
+		t.Fatalf("ReadLen = %d, want 4", op.ReadLen)
+	}
+
+	// T1 = 123456 -> 1234.56, T2 = 654321 -> 6543.21
+	b := []byte{0x00, 0x01, 0xE2, 0x40, 0x00, 0x09, 0xFB, 0xF1}
+
+	res := op.Splitter(b)
+	if len(res) != 3 {
+		t.Fatalf("Splitter returned %d results, want 3: %+v", len(res), res)
+	}
+
+	want := map[meters.Measurement]float64{
+		meters.ImportT1: 1234.56,
+		meters.ImportT2: 6543.21,
+		meters.Import:   1234.56 + 6543.21,
+	}
+
+	for _, r := range res {
+		wantVal, ok := want[r.IEC61850]
+		if !ok {
+			t.Errorf("unexpected measurement %v in results", r.IEC61850)
+			continue
+		}
+		if r.Value != wantVal {
+			t.Errorf("%v = %v, want %v", r.IEC61850, r.Value, wantVal)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package rs485
+
+import (
+	"github.com/RichieB2B/mbmd/internal/meters"
+)
+
+// Sungrow register addresses (0-based input register / function code 4).
+// EXPERIMENTAL: these were transcribed from community reverse-engineering
+// notes for the SG/SH string and hybrid inverter Modbus protocol, not a
+// Sungrow-published register map, and have not been verified against a
+// real device by this producer's author. Treat readings with suspicion
+// and confirm against your inverter's own documentation or a packet
+// capture before relying on them; adjust for a given firmware revision.
+const (
+	sungrowManufacturerCode = 4999 // discriminator register used for autodetect
+
+	sungrowDailyYield   = 5003 // uint16, x0.1 kWh
+	sungrowTotalYieldH  = 5004 // uint32 (word-swapped, low word first), x0.1 kWh
+	sungrowRunningHours = 5006 // uint32, h
+	sungrowTemp         = 5008 // int16, x0.1 degC
+
+	sungrowPV1Voltage = 5011 // uint16, x0.1 V
+	sungrowPV1Current = 5012 // uint16, x0.1 A
+	sungrowPV2Voltage = 5013 // not populated on single-MPPT models -> 0xffff
+	sungrowPV2Current = 5014
+	sungrowPV3Voltage = 5015 // not populated below 3 MPPT -> 0xffff
+	sungrowPV3Current = 5016
+
+	sungrowDCPower = 5017 // uint32, W
+
+	sungrowVoltageL1 = 5019 // uint16, x0.1 V
+	sungrowVoltageL2 = 5020
+	sungrowVoltageL3 = 5021
+	sungrowCurrentL1 = 5022 // uint16, x0.1 A
+	sungrowCurrentL2 = 5023
+	sungrowCurrentL3 = 5024
+
+	sungrowPower = 5031 // uint32, W
+	sungrowFreq  = 5035 // uint16, x0.1 Hz
+
+	// hybrid (SH) models only - battery telemetry lives in the 13xxx input
+	// register range, not adjacent to the inverter AC/DC block above.
+	sungrowBatteryVoltage = 13020 // uint16, x0.1 V
+	sungrowBatteryCurrent = 13021 // int16, x0.1 A
+	sungrowBatteryPower   = 13022 // int16, signed, W - positive charges, negative discharges
+	sungrowBatterySOC     = 13023 // uint16, x0.1 %
+)
+
+func snip16(fc meters.FuncCode, addr uint16, iec meters.Measurement, scale float64) meters.Operation {
+	return meters.Operation{
+		FuncCode:  fc,
+		OpCode:    addr,
+		ReadLen:   1,
+		IEC61850:  iec,
+		Transform: meters.MakeRTUScaledUint16ToFloat64(scale),
+	}
+}
+
+func snip16Signed(fc meters.FuncCode, addr uint16, iec meters.Measurement, scale float64) meters.Operation {
+	return meters.Operation{
+		FuncCode:  fc,
+		OpCode:    addr,
+		ReadLen:   1,
+		IEC61850:  iec,
+		Transform: meters.MakeRTUScaledInt16ToFloat64(scale),
+	}
+}
+
+func snip16WithNaN(fc meters.FuncCode, addr uint16, iec meters.Measurement, scale float64) meters.Operation {
+	return meters.Operation{
+		FuncCode: fc,
+		OpCode:   addr,
+		ReadLen:  1,
+		IEC61850: iec,
+		Transform: func(b []byte) float64 {
+			return scale * meters.RTUUint16ToFloat64WithNaN(b)
+		},
+	}
+}
+
+// snip32 decodes a 32 bit Sungrow register pair. Sungrow SG/SH inverters
+// return these word-swapped (CDAB) rather than plain big-endian.
+func snip32(fc meters.FuncCode, addr uint16, iec meters.Measurement, scale float64) meters.Operation {
+	return meters.Operation{
+		FuncCode:  fc,
+		OpCode:    addr,
+		ReadLen:   2,
+		IEC61850:  iec,
+		Transform: meters.MakeRTUScaledUint32ToFloat64Ordered(scale, meters.CDAB),
+	}
+}
+
+// Sungrow decodes Sungrow SG/SH residential string and hybrid inverters.
+// Unlike the SunSpec producers it reads fixed input register addresses,
+// one operation per point, from 5003 up (and from 13020 up for battery
+// telemetry), the layout Sungrow uses on its own Modbus protocol.
+type Sungrow struct{}
+
+// NewSungrowProducer creates a Sungrow producer.
+func NewSungrowProducer() *Sungrow {
+	return &Sungrow{}
+}
+
+func (p *Sungrow) Type() string {
+	return "SUNGROW"
+}
+
+func (p *Sungrow) Description() string {
+	return "Sungrow SG/SH series string/hybrid inverter (experimental, unverified register map)"
+}
+
+// Probe reads the manufacturer code register used to discriminate Sungrow
+// devices during RS485 autodetect.
+func (p *Sungrow) Probe() meters.Operation {
+	return meters.Operation{
+		FuncCode: meters.ReadInputReg,
+		OpCode:   sungrowManufacturerCode,
+		ReadLen:  1,
+	}
+}
+
+// Produce implements meters.Producer.
+func (p *Sungrow) Produce() (res []meters.Operation) {
+	res = append(res,
+		snip16WithNaN(meters.ReadInputReg, sungrowPV1Voltage, meters.DCVoltageL1, 0.1),
+		snip16WithNaN(meters.ReadInputReg, sungrowPV1Current, meters.DCCurrentL1, 0.1),
+		snip16WithNaN(meters.ReadInputReg, sungrowPV2Voltage, meters.DCVoltageL2, 0.1),
+		snip16WithNaN(meters.ReadInputReg, sungrowPV2Current, meters.DCCurrentL2, 0.1),
+		snip16WithNaN(meters.ReadInputReg, sungrowPV3Voltage, meters.DCVoltageL3, 0.1),
+		snip16WithNaN(meters.ReadInputReg, sungrowPV3Current, meters.DCCurrentL3, 0.1),
+		snip32(meters.ReadInputReg, sungrowDCPower, meters.DCPower, 1),
+
+		snip16(meters.ReadInputReg, sungrowVoltageL1, meters.VoltageL1, 0.1),
+		snip16(meters.ReadInputReg, sungrowVoltageL2, meters.VoltageL2, 0.1),
+		snip16(meters.ReadInputReg, sungrowVoltageL3, meters.VoltageL3, 0.1),
+		snip16(meters.ReadInputReg, sungrowCurrentL1, meters.CurrentL1, 0.1),
+		snip16(meters.ReadInputReg, sungrowCurrentL2, meters.CurrentL2, 0.1),
+		snip16(meters.ReadInputReg, sungrowCurrentL3, meters.CurrentL3, 0.1),
+		snip32(meters.ReadInputReg, sungrowPower, meters.Power, 1),
+		snip16(meters.ReadInputReg, sungrowFreq, meters.Freq, 0.1),
+
+		snip16Signed(meters.ReadInputReg, sungrowTemp, meters.Temp, 0.1),
+
+		snip16(meters.ReadInputReg, sungrowDailyYield, meters.ImportDaily, 0.1),
+		snip32(meters.ReadInputReg, sungrowTotalYieldH, meters.Import, 0.1),
+		snip32(meters.ReadInputReg, sungrowRunningHours, meters.OperatingTime, 1),
+	)
+
+	return append(res, p.batteryOperations()...)
+}
+
+// batteryOperations covers battery telemetry present on the hybrid (SH)
+// models. On plain string (SG) inverters these registers are read as well
+// and whatever they return is reported as is; no sentinel filtering is
+// applied to them.
+func (p *Sungrow) batteryOperations() []meters.Operation {
+	return []meters.Operation{
+		snip16(meters.ReadInputReg, sungrowBatterySOC, meters.ChargeState, 0.1),
+		snip16Signed(meters.ReadInputReg, sungrowBatteryPower, meters.BatteryPower, 1),
+		snip16(meters.ReadInputReg, sungrowBatteryVoltage, meters.BatteryVoltage, 0.1),
+		snip16Signed(meters.ReadInputReg, sungrowBatteryCurrent, meters.BatteryCurrent, 0.1),
+	}
+}
+
+func init() {
+	meters.Register(Sungrow{}.Type(), func() meters.Producer {
+		return NewSungrowProducer()
+	})
+}
@@ -3,28 +3,100 @@ package meters
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"math"
 	"strings"
 )
 
 const (
 	// MODBUS protocol address (base 0)
-	sunspecBase         = 40000
-	sunspecID           = 1
-	sunspecModelID      = 3
-	sunspecManufacturer = 5
-	sunspecModel        = 21
-	sunspecVersion      = 45
-	sunspecSerial       = 53
+	sunspecBase          = 40000
+	sunspecID            = 1
+	sunspecModelID       = 3
+	sunspecManufacturer  = 5
+	sunspecModel         = 21
+	sunspecVersion       = 45
+	sunspecSerial        = 53
+	sunspecDeviceAddress = sunspecSerial + 16 // DA, the common model's Modbus device address (uint16)
+	// sunspecMAC is NOT part of the SunSpec common model - it's a
+	// non-standard vendor extension some devices append right after DA.
+	// Treat MAC as absent unless the register actually holds one - adjust
+	// according to docs for the device at hand.
+	sunspecMAC = sunspecDeviceAddress + 1
 
 	sunsSignature = 0x53756e53 // SunS
+
+	// sunspecEndModelID terminates the SunSpec model chain
+	sunspecEndModelID = 0xFFFF
 )
 
+// sunSpecModelBlock describes where a discovered model's data starts and
+// how many registers it spans, relative to the start of the buffer the
+// model chain was parsed from.
+type sunSpecModelBlock struct {
+	base   uint16
+	length uint16
+}
+
+// sunSpecMaxModelChainRegisters bounds how far parseSunSpecModelChain will
+// walk past sunspecBase before giving up on ever finding an End model
+// (0xFFFF). Without a bound, a device that never returns the End model, or
+// returns a model with length 0, would advance forever (and eventually
+// wrap uint16 arithmetic) instead of failing.
+const sunSpecMaxModelChainRegisters = 1000
+
+// parseSunSpecModelChain walks the (modelID, length) header chain inside an
+// already-read buffer starting at sunspecBase, verifying the "SunS"
+// signature first. It returns the base/length of every model found, in
+// registers relative to the start of b. If b ends before the End model, the
+// models found up to that point are returned, and the last one's block may
+// extend past the end of b. Use this instead of assuming a
+// fixed offset from 40000 - the block layout differs between manufacturers
+// (Fronius, SolarEdge, Kostal, SMA, ...).
+func parseSunSpecModelChain(b []byte) (map[uint16]sunSpecModelBlock, error) {
+	if len(b) < 4 || binary.BigEndian.Uint32(b) != sunsSignature {
+		return nil, errors.New("Invalid SunSpec device signature")
+	}
+
+	blocks := make(map[uint16]sunSpecModelBlock)
+
+	addr := uint16(2) // registers, relative to b[0] == sunspecBase
+	for addr < sunSpecMaxModelChainRegisters {
+		if int(addr+2)*2 > len(b) {
+			// the read ended before the End model: return what was found,
+			// callers check each block against the buffer themselves
+			return blocks, nil
+		}
+
+		hdr := b[2*addr:]
+		modelID := binary.BigEndian.Uint16(hdr)
+		if modelID == sunspecEndModelID {
+			return blocks, nil
+		}
+
+		length := binary.BigEndian.Uint16(hdr[2:])
+		if length == 0 {
+			return nil, fmt.Errorf("SunSpec model %d declares zero length", modelID)
+		}
+		if int(addr)+2+int(length) >= sunSpecMaxModelChainRegisters {
+			break // computed in int so a huge length cannot wrap addr back
+		}
+
+		base := addr + 2
+		blocks[modelID] = sunSpecModelBlock{base: base, length: length}
+
+		addr = base + length
+	}
+
+	return nil, errors.New("SunSpec model chain did not terminate within the expected range")
+}
+
 type SunSpecDeviceDescriptor struct {
 	Manufacturer string
 	Model        string
 	Version      string
 	Serial       string
+	MAC          string // optional EUI-48 identifier, populated if present in the common block
 }
 
 // RTUUint16ToFloat64WithNaN converts 16 bit unsigned integer readings
@@ -37,8 +109,92 @@ func RTUUint16ToFloat64WithNaN(b []byte) float64 {
 	return float64(u)
 }
 
+// RTUInt16ToFloat64WithNaN converts 16 bit signed integer readings
+// If byte sequence is 0x7fff, NaN is returned for compatibility with devices
+// that use this sentinel for an unpopulated optional channel
+func RTUInt16ToFloat64WithNaN(b []byte) float64 {
+	i := int16(binary.BigEndian.Uint16(b))
+	if i == 0x7fff {
+		return math.NaN()
+	}
+	return float64(i)
+}
+
+// WordOrder selects how a multi-register SunSpec value's words are ordered
+// on the wire. Most devices use ABCD (plain big-endian), but some SMA and
+// Kostal firmwares return 32 bit values word-swapped or byte-swapped.
+type WordOrder int
+
+const (
+	ABCD WordOrder = iota // big-endian, default
+	CDAB                  // words swapped
+	BADC                  // bytes swapped within each word
+	DCBA                  // fully reversed (little-endian)
+)
+
+// UnmarshalYAML lets WordOrder be set directly from a device's YAML config,
+// e.g. "wordorder: CDAB", instead of only via the snip32Ordered per-point
+// override.
+func (o *WordOrder) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	switch strings.ToUpper(s) {
+	case "", "ABCD":
+		*o = ABCD
+	case "CDAB":
+		*o = CDAB
+	case "BADC":
+		*o = BADC
+	case "DCBA":
+		*o = DCBA
+	default:
+		return fmt.Errorf("unknown SunSpec word order %q", s)
+	}
+
+	return nil
+}
+
+// reorderWords rearranges b from the given WordOrder into plain
+// big-endian (ABCD) so the existing RTU*ToFloat64 helpers can be used
+// unchanged.
+func reorderWords(b []byte, order WordOrder) []byte {
+	if order == ABCD {
+		return b
+	}
+
+	out := make([]byte, len(b))
+	switch order {
+	case CDAB:
+		for i := 0; i < len(b); i += 2 {
+			src := len(b) - 2 - i
+			out[i], out[i+1] = b[src], b[src+1]
+		}
+	case BADC:
+		for i := 0; i+1 < len(b); i += 2 {
+			out[i], out[i+1] = b[i+1], b[i]
+		}
+	case DCBA:
+		for i := range b {
+			out[i] = b[len(b)-1-i]
+		}
+	default:
+		copy(out, b)
+	}
+
+	return out
+}
+
 type SunSpecCore struct {
 	MeasurementMapping
+
+	// WordOrder is the producer-level default word order used to decode
+	// 32 bit SunSpec points. It is populated from the device's YAML
+	// config and defaults to ABCD; individual points can still override
+	// it by calling the snip32Ordered/mkSplitUint32Ordered variants.
+	WordOrder WordOrder `yaml:"wordorder"`
 }
 
 func (p *SunSpecCore) GetSunSpecCommonBlock() Operation {
@@ -46,7 +202,7 @@ func (p *SunSpecCore) GetSunSpecCommonBlock() Operation {
 	return Operation{
 		FuncCode: ReadHoldingReg,
 		OpCode:   sunspecBase, // adjust according to docs
-		ReadLen:  sunspecSerial,
+		ReadLen:  sunspecMAC + 3,
 		// IEC61850: iec,
 	}
 }
@@ -69,9 +225,41 @@ func (p *SunSpecCore) DecodeSunSpecCommonBlock(b []byte) (SunSpecDeviceDescripto
 	res.Version = p.stringDecode(b, sunspecVersion, 8)
 	res.Serial = p.stringDecode(b, sunspecSerial, 16)
 
+	// The MAC extension is not part of the standard common model: without it
+	// the registers after DA hold the Pad point or the next model's header.
+	// It is only decoded when the common model's declared length (the
+	// register after its model ID) covers all four MAC registers.
+	commonLen := int(binary.BigEndian.Uint16(b[2*sunspecModelID:]))
+	if macStart := 2 * (sunspecMAC - 1); sunspecModelID+1+commonLen >= sunspecMAC+3 && len(b) >= macStart+8 {
+		mac := b[macStart : macStart+8]
+		if !allBytesEqual(mac, 0x00) && !allBytesEqual(mac, 0xFF) {
+			res.MAC = decodeEui48(mac)
+		}
+	}
+
 	return res, nil
 }
 
+// decodeEui48 decodes an 8 byte SunSpec EUI-48 point into a MAC-style
+// string. The first register is reserved; only bytes 2..7 carry the
+// address.
+func decodeEui48(b []byte) string {
+	mac := b[2:8]
+	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", mac[0], mac[1], mac[2], mac[3], mac[4], mac[5])
+}
+
+// allBytesEqual reports whether every byte in b equals v, used to treat an
+// all-0x00/all-0xFF MAC extension register as "not present" rather than as
+// a real (bogus) address.
+func allBytesEqual(b []byte, v byte) bool {
+	for _, c := range b {
+		if c != v {
+			return false
+		}
+	}
+	return true
+}
+
 func (p *SunSpecCore) stringDecode(b []byte, reg int, len int) string {
 	start := 2 * (reg - 1)
 	end := 2 * (reg + len - 1)
@@ -111,16 +299,37 @@ func (p *SunSpecCore) snip16int(iec Measurement, scaler ...float64) Operation {
 }
 
 func (p *SunSpecCore) snip32(iec Measurement, scaler ...float64) Operation {
+	return p.snip32Ordered(iec, p.WordOrder, scaler...)
+}
+
+// snip32Ordered is like snip32 but lets a single point override the
+// producer-level WordOrder, for devices that mix word orders across models.
+func (p *SunSpecCore) snip32Ordered(iec Measurement, order WordOrder, scaler ...float64) Operation {
 	snip := p.snip(iec, 2)
 
-	snip.Transform = RTUUint32ToFloat64 // default conversion
+	conv := RTUUint32ToFloat64 // default conversion
 	if len(scaler) > 0 {
-		snip.Transform = MakeRTUScaledUint32ToFloat64(scaler[0])
+		conv = MakeRTUScaledUint32ToFloat64(scaler[0])
+	}
+
+	snip.Transform = func(b []byte) float64 {
+		return conv(reorderWords(b[:4], order))
 	}
 
 	return snip
 }
 
+// MakeRTUScaledUint32ToFloat64Ordered is MakeRTUScaledUint32ToFloat64 with
+// an explicit WordOrder, for producers outside this package that need to
+// decode a 32 bit value whose words do not arrive plain big-endian (ABCD) -
+// e.g. Sungrow's word-swapped (CDAB) totals and power readings.
+func MakeRTUScaledUint32ToFloat64Ordered(scale float64, order WordOrder) func([]byte) float64 {
+	conv := MakeRTUScaledUint32ToFloat64(scale)
+	return func(b []byte) float64 {
+		return conv(reorderWords(b[:4], order))
+	}
+}
+
 func (p *SunSpecCore) minMax(iec ...Measurement) (uint16, uint16) {
 	var min = uint16(0xFFFF)
 	var max = uint16(0x0000)
@@ -167,8 +376,17 @@ func (p *SunSpecCore) mkSplitUint16(iecs ...Measurement) Splitter {
 }
 
 func (p *SunSpecCore) mkSplitUint32(iecs ...Measurement) Splitter {
+	return p.mkSplitUint32Ordered(p.WordOrder, iecs...)
+}
+
+// mkSplitUint32Ordered is like mkSplitUint32 but lets a single block
+// override the producer-level WordOrder.
+func (p *SunSpecCore) mkSplitUint32Ordered(order WordOrder, iecs ...Measurement) Splitter {
 	// use div 1000 for kWh conversion
-	return p.mkBlockSplitter(4, MakeRTUScaledUint32ToFloat64(1000), iecs...)
+	conv := MakeRTUScaledUint32ToFloat64(1000)
+	return p.mkBlockSplitter(4, func(b []byte) float64 {
+		return conv(reorderWords(b[:4], order))
+	}, iecs...)
 }
 
 func (p *SunSpecCore) mkBlockSplitter(dataSize uint16, valFunc func([]byte) float64, iecs ...Measurement) Splitter {
@@ -0,0 +1,111 @@
+package meters
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// sunSpecNoSF marks a point that carries no accompanying scale factor.
+const sunSpecNoSF = 0xFFFF
+
+// sunSpecPoint describes a single data point inside a SunSpec model's data
+// block (the part that follows the model's (modelID, length) header).
+// Offset and SFOffset are 0-based register offsets relative to the start
+// of that data block.
+type sunSpecPoint struct {
+	IEC61850 Measurement
+	Offset   uint16
+	Signed   bool
+	Wide     bool   // true for acc32 points (2 registers), e.g. lifetime energy counters
+	SFOffset uint16 // offset of the associated _SF point, or sunSpecNoSF
+}
+
+// decodeSunSpecPoints turns a raw model data block into SplitResults by
+// applying each point's type conversion and associated scale factor. Points
+// whose value uses the "not implemented" sentinel (0xFFFF/0x7FFF) are
+// dropped, mirroring RTUUint16ToFloat64WithNaN.
+func (p *SunSpecCore) decodeSunSpecPoints(b []byte, points []sunSpecPoint) []SplitResult {
+	res := make([]SplitResult, 0, len(points))
+
+	for _, pt := range points {
+		var val float64
+		switch {
+		case pt.Wide:
+			val = RTUUint32ToFloat64(b[2*pt.Offset:])
+		case pt.Signed:
+			val = RTUInt16ToFloat64WithNaN(b[2*pt.Offset:])
+		default:
+			val = RTUUint16ToFloat64WithNaN(b[2*pt.Offset:])
+		}
+
+		if math.IsNaN(val) {
+			continue
+		}
+
+		if pt.SFOffset != sunSpecNoSF {
+			sf := int16(binary.BigEndian.Uint16(b[2*pt.SFOffset:]))
+			val *= math.Pow10(int(sf))
+		}
+
+		res = append(res, SplitResult{
+			IEC61850: pt.IEC61850,
+			Value:    val,
+		})
+	}
+
+	return res
+}
+
+// SunSpec inverter models 101 (single phase), 102 (split phase) and 103
+// (three phase) share a common layout - the phase-specific points simply
+// go unused/not-implemented on models with fewer phases. Offsets follow
+// the model's own point list: A/AphA/AphB/AphC/A_SF, PPVphAB..PPVphCA,
+// PhVphA/B/C, V_SF, W, W_SF, Hz, Hz_SF, VA, VA_SF, VAR, VAR_SF, PF, PF_SF,
+// WH (acc32), WH_SF, DCA, DCA_SF, DCV, DCV_SF, DCW, DCW_SF,
+// TmpCab/Snk/Trns/Ot, Tmp_SF, St, ...
+var sunSpecInverterPoints = []sunSpecPoint{
+	{IEC61850: CurrentL1, Offset: 1, SFOffset: 4},
+	{IEC61850: CurrentL2, Offset: 2, SFOffset: 4},
+	{IEC61850: CurrentL3, Offset: 3, SFOffset: 4},
+	{IEC61850: VoltageL1, Offset: 8, SFOffset: 11}, // PhVphA
+	{IEC61850: VoltageL2, Offset: 9, SFOffset: 11}, // PhVphB
+	{IEC61850: VoltageL3, Offset: 10, SFOffset: 11}, // PhVphC
+	{IEC61850: Power, Offset: 12, Signed: true, SFOffset: 13},
+	{IEC61850: Freq, Offset: 14, SFOffset: 15},
+	{IEC61850: Import, Offset: 22, Wide: true, SFOffset: 24}, // WH, AC lifetime energy (Wh)
+	{IEC61850: DCCurrent, Offset: 25, SFOffset: 26},
+	{IEC61850: DCVoltage, Offset: 27, SFOffset: 28},
+	{IEC61850: DCPower, Offset: 29, Signed: true, SFOffset: 30},
+	{IEC61850: Temp, Offset: 31, Signed: true, SFOffset: 35}, // TmpCab, Tmp_SF
+}
+
+// DecodeSunSpecInverterModel decodes the data block of inverter models
+// 101/102/103 as sliced out of the discovery read by sunSpecModelData.
+func (p *SunSpecCore) DecodeSunSpecInverterModel(b []byte) []SplitResult {
+	return p.decodeSunSpecPoints(b, sunSpecInverterPoints)
+}
+
+// SunSpec meter models 201 (single phase), 202 (split phase), 203 (wye
+// three phase) and 204 (delta three phase) share a common layout. Offsets
+// follow the model's own point list: A/AphA/AphB/AphC/A_SF, PhV, PhVphA/
+// B/C, PPV, PPVphAB..PPVphCA, V_SF, Hz, Hz_SF, W, WphA/B/C, W_SF, VA...,
+// VAR..., PF..., TotWhExp (acc32), TotWhExpPhA/B/C, TotWhImp (acc32),
+// TotWhImpPhA/B/C, TotWh_SF, ...
+var sunSpecMeterPoints = []sunSpecPoint{
+	{IEC61850: CurrentL1, Offset: 1, Signed: true, SFOffset: 4},
+	{IEC61850: CurrentL2, Offset: 2, Signed: true, SFOffset: 4},
+	{IEC61850: CurrentL3, Offset: 3, Signed: true, SFOffset: 4},
+	{IEC61850: VoltageL1, Offset: 6, Signed: true, SFOffset: 13}, // PhVphA
+	{IEC61850: VoltageL2, Offset: 7, Signed: true, SFOffset: 13}, // PhVphB
+	{IEC61850: VoltageL3, Offset: 8, Signed: true, SFOffset: 13}, // PhVphC
+	{IEC61850: Freq, Offset: 14, Signed: true, SFOffset: 15},
+	{IEC61850: Power, Offset: 16, Signed: true, SFOffset: 20},
+	{IEC61850: Export, Offset: 36, Wide: true, SFOffset: 52}, // TotWhExp
+	{IEC61850: Import, Offset: 44, Wide: true, SFOffset: 52}, // TotWhImp
+}
+
+// DecodeSunSpecMeterModel decodes the data block of meter models
+// 201/202/203/204 as sliced out of the discovery read by sunSpecModelData.
+func (p *SunSpecCore) DecodeSunSpecMeterModel(b []byte) []SplitResult {
+	return p.decodeSunSpecPoints(b, sunSpecMeterPoints)
+}
@@ -0,0 +1,99 @@
+package meters
+
+// sunSpecDiscoveryReadLen is a single generous read covering the common
+// model plus enough headroom for the model header chain and the data block
+// of whichever inverter/meter model follows it. Discovery used to require a
+// Connection round trip per model header; reading it as one block instead
+// lets the whole walk run inside a Splitter, which is the only hook mbmd
+// actually calls via Produce().
+const sunSpecDiscoveryReadLen = 300
+
+// GenericSunSpec is a SunSpec producer that discovers a device's model
+// chain instead of assuming every point sits at a fixed offset from
+// register 40000 - the block layout differs between manufacturers
+// (Fronius, SolarEdge, Kostal, SMA, ...).
+type GenericSunSpec struct {
+	SunSpecCore
+}
+
+// NewGenericSunSpecProducer creates a GenericSunSpec producer.
+func NewGenericSunSpecProducer() *GenericSunSpec {
+	return &GenericSunSpec{}
+}
+
+func (p *GenericSunSpec) Type() string {
+	return "SUNSPEC"
+}
+
+func (p *GenericSunSpec) Description() string {
+	return "Generic SunSpec-compliant inverter/meter"
+}
+
+// Probe reads the SunSpec common block used to identify the device.
+func (p *GenericSunSpec) Probe() Operation {
+	return p.GetSunSpecCommonBlock()
+}
+
+// Produce implements meters.Producer. The model chain isn't known until
+// it's read, so this issues one generous read starting at sunspecBase and
+// leaves model discovery and decoding to splitSunSpecModels instead of
+// assuming a fixed register offset.
+func (p *GenericSunSpec) Produce() []Operation {
+	return []Operation{
+		{
+			FuncCode: ReadHoldingReg,
+			OpCode:   sunspecBase,
+			ReadLen:  sunSpecDiscoveryReadLen,
+			IEC61850: Split,
+			Splitter: p.splitSunSpecModels,
+		},
+	}
+}
+
+// splitSunSpecModels walks the model chain inside a single read of the
+// device's SunSpec register map and decodes whichever inverter/meter model
+// it finds there. A model whose data block falls outside the read (the
+// device's chain runs past sunSpecDiscoveryReadLen) is skipped, same as a
+// malformed chain.
+func (p *GenericSunSpec) splitSunSpecModels(b []byte) []SplitResult {
+	models, err := parseSunSpecModelChain(b)
+	if err != nil {
+		return nil
+	}
+
+	var res []SplitResult
+
+	for _, modelID := range []uint16{101, 102, 103} {
+		if blk, ok := models[modelID]; ok {
+			if data, ok := sunSpecModelData(b, blk); ok {
+				res = append(res, p.DecodeSunSpecInverterModel(data)...)
+			}
+		}
+	}
+
+	for _, modelID := range []uint16{201, 202, 203, 204} {
+		if blk, ok := models[modelID]; ok {
+			if data, ok := sunSpecModelData(b, blk); ok {
+				res = append(res, p.DecodeSunSpecMeterModel(data)...)
+			}
+		}
+	}
+
+	return res
+}
+
+// sunSpecModelData slices a model's data block out of the buffer its chain
+// was parsed from, or reports false if the block runs past what was read.
+func sunSpecModelData(b []byte, blk sunSpecModelBlock) ([]byte, bool) {
+	start, end := 2*int(blk.base), 2*int(blk.base+blk.length)
+	if end > len(b) {
+		return nil, false
+	}
+	return b[start:end], true
+}
+
+func init() {
+	Register(GenericSunSpec{}.Type(), func() Producer {
+		return NewGenericSunSpecProducer()
+	})
+}
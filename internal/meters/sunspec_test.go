@@ -0,0 +1,92 @@
+package meters
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestReorderWords(t *testing.T) {
+	// ABCD input; expected output for each order, given as the bytes
+	// reorderWords must rearrange back into plain big-endian.
+	abcd := []byte{0xAA, 0xBB, 0xCC, 0xDD}
+
+	tests := []struct {
+		name  string
+		order WordOrder
+		in    []byte
+		want  []byte
+	}{
+		{"ABCD", ABCD, abcd, []byte{0xAA, 0xBB, 0xCC, 0xDD}},
+		{"CDAB", CDAB, []byte{0xCC, 0xDD, 0xAA, 0xBB}, abcd},
+		{"BADC", BADC, []byte{0xBB, 0xAA, 0xDD, 0xCC}, abcd},
+		{"DCBA", DCBA, []byte{0xDD, 0xCC, 0xBB, 0xAA}, abcd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reorderWords(tt.in, tt.order)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reorderWords(%x, %v) = %x, want %x", tt.in, tt.order, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeSunSpecPoints(t *testing.T) {
+	// Three points: a plain uint16 at offset 0 (Power) scaled by the int16
+	// scale factor at offset 4, a wide (acc32) counter at offset 1 (Import)
+	// and a signed int16 at offset 3 (Temp), the last two without a scale
+	// factor.
+	points := []sunSpecPoint{
+		{IEC61850: Power, Offset: 0, SFOffset: 4},
+		{IEC61850: Import, Offset: 1, Wide: true, SFOffset: sunSpecNoSF},
+		{IEC61850: Temp, Offset: 3, Signed: true, SFOffset: sunSpecNoSF},
+	}
+
+	b := make([]byte, 2*6)
+	binary.BigEndian.PutUint16(b[0:], 1234)              // Power raw
+	binary.BigEndian.PutUint32(b[2:], 500000)             // Import raw (acc32)
+	binary.BigEndian.PutUint16(b[6:], uint16(int16(-5)))  // Temp raw (signed)
+	binary.BigEndian.PutUint16(b[8:], uint16(int16(-1)))  // scale factor -1 -> x0.1
+
+	p := &SunSpecCore{}
+	res := p.decodeSunSpecPoints(b, points)
+
+	want := map[Measurement]float64{
+		Power:  123.4,
+		Import: 500000,
+		Temp:   -5,
+	}
+
+	if len(res) != len(want) {
+		t.Fatalf("decodeSunSpecPoints returned %d results, want %d: %+v", len(res), len(want), res)
+	}
+
+	for _, r := range res {
+		wantVal, ok := want[r.IEC61850]
+		if !ok {
+			t.Errorf("unexpected measurement %v in results", r.IEC61850)
+			continue
+		}
+		if r.Value != wantVal {
+			t.Errorf("%v = %v, want %v", r.IEC61850, r.Value, wantVal)
+		}
+	}
+}
+
+func TestDecodeSunSpecPointsDropsNotImplemented(t *testing.T) {
+	points := []sunSpecPoint{
+		{IEC61850: Power, Offset: 0, SFOffset: sunSpecNoSF},
+	}
+
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, 0xFFFF) // "not implemented" sentinel
+
+	p := &SunSpecCore{}
+	res := p.decodeSunSpecPoints(b, points)
+
+	if len(res) != 0 {
+		t.Fatalf("decodeSunSpecPoints = %+v, want no results for a not-implemented point", res)
+	}
+}